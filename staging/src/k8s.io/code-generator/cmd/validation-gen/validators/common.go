@@ -17,6 +17,9 @@ limitations under the License.
 package validators
 
 import (
+	"fmt"
+	"strings"
+
 	"k8s.io/gengo/v2/parser/tags"
 	"k8s.io/gengo/v2/types"
 )
@@ -27,15 +30,71 @@ const (
 	libValidationPkg = "k8s.io/apimachinery/pkg/api/validate"
 )
 
-func getMemberByJSON(t *types.Type, jsonName string) *types.Member {
+// getMemberByJSON returns the member of t whose JSON name is jsonName,
+// together with the chain of any `json:",inline"` or Go-anonymous embedded
+// members it had to descend through to reach it (not including the returned
+// member itself). Both kinds of field promote their own members into t's
+// JSON representation, and either can itself be a pointer, so callers that
+// need to emit nil-guard checks (see isNilableType) must consider this chain
+// too, not just the member they asked for.
+func getMemberByJSON(t *types.Type, jsonName string) (*types.Member, []*types.Member) {
 	for i := range t.Members {
-		if jsonTag, ok := tags.LookupJSON(t.Members[i]); ok {
+		m := &t.Members[i]
+		if jsonTag, ok := tags.LookupJSON(*m); ok {
 			if jsonTag.Name == jsonName {
-				return &t.Members[i]
+				return m, nil
+			}
+			if jsonTag.Inline {
+				if found, hops := getMemberByJSON(nonPointer(nativeType(m.Type)), jsonName); found != nil {
+					return found, append([]*types.Member{m}, hops...)
+				}
+			}
+			continue
+		}
+		if m.Embedded {
+			if found, hops := getMemberByJSON(nonPointer(nativeType(m.Type)), jsonName); found != nil {
+				return found, append([]*types.Member{m}, hops...)
 			}
 		}
 	}
-	return nil
+	return nil, nil
+}
+
+// getMemberByJSONPath resolves a dotted JSON path (e.g.
+// "spec.template.metadata.name") against t, one segment at a time, using
+// getMemberByJSON to resolve each segment so that embedded and `,inline`
+// fields are traversed transparently. It returns the terminal member along
+// with the ordered chain of parent members traversed to reach it, including
+// any inline/embedded hops within a segment, so callers can emit nil-guard
+// checks (see isNilableType) for any pointer hops along the way.
+func getMemberByJSONPath(t *types.Type, jsonPath string) (*types.Member, []*types.Member, error) {
+	segments := strings.Split(jsonPath, ".")
+	if len(segments) == 0 || segments[0] == "" {
+		return nil, nil, fmt.Errorf("empty JSON path")
+	}
+
+	var parents []*types.Member
+	cur := t
+	for i, seg := range segments {
+		m, hops := getMemberByJSON(cur, seg)
+		if m == nil {
+			return nil, nil, fmt.Errorf("no field with JSON name %q in %s (resolving path %q)", seg, cur, jsonPath)
+		}
+		if i == len(segments)-1 {
+			return m, append(parents, hops...), nil
+		}
+		parents = append(parents, hops...)
+		parents = append(parents, m)
+
+		next := nonPointer(nativeType(m.Type))
+		if next.Kind != types.Struct {
+			return nil, nil, fmt.Errorf("cannot descend into %q: %s is a %s, not a struct", seg, next, next.Kind)
+		}
+		cur = next
+	}
+	// Unreachable: segments is non-empty (checked above), so every
+	// iteration of the loop above returns.
+	panic("unreachable")
 }
 
 // isNilableType returns true if the argument type can be compared to nil.
@@ -43,6 +102,15 @@ func isNilableType(t *types.Type) bool {
 	for t.Kind == types.Alias {
 		t = t.Underlying
 	}
+	if t.Kind == types.TypeParam {
+		// A type parameter is nilable iff its constraint's single core
+		// type, as resolved by structuralType, is nilable (e.g. `T any`'s
+		// structural type is an interface, which is nilable). This does
+		// not evaluate disjoint union constraints (e.g. `T int | string`)
+		// term by term; such constraints have no single core type and
+		// structuralType does not attempt to reason about them.
+		return isNilableType(structuralType(t))
+	}
 	switch t.Kind {
 	case types.Pointer, types.Map, types.Slice, types.Interface: // Note: Arrays are not nilable
 		return true
@@ -50,9 +118,46 @@ func isNilableType(t *types.Type) bool {
 	return false
 }
 
+// structuralType resolves a generic type to its structural, non-generic
+// form. Type parameters are resolved to their constraint's structural type,
+// and instantiated named types (e.g. `List[Pod]`) are collapsed to their
+// post-substitution underlying type. Like nativeType, it preserves the
+// pointer-count invariant: pointers wrapping a type parameter or an
+// instantiated named type survive the resolution.
+//
+// Examples:
+//   - given `type List[T any] []T; List[Pod]`, returns `[]Pod`
+//   - given `func Foo[T any](T)` with `T`'s constraint structurally `any`,
+//     returns `interface{}` for `T`
+//   - given `*T` where `T`'s structural type is `int`, returns `*int`
+func structuralType(t *types.Type) *types.Type {
+	ptrs := 0
+	for {
+		switch {
+		case t.Kind == types.Pointer:
+			ptrs++
+			t = t.Elem
+		case t.Kind == types.Alias || t.Kind == types.TypeParam:
+			// A type parameter's Underlying is its constraint's
+			// structural type; an instantiated named type's Underlying
+			// is already substituted with its type arguments.
+			t = t.Underlying
+		case len(t.TypeArgs) > 0 && t.Underlying != nil:
+			t = t.Underlying
+		default:
+			for range ptrs {
+				t = types.PointerTo(t)
+			}
+			return t
+		}
+	}
+}
+
 // nativeType returns the Go native type of the argument type, with any
 // intermediate typedefs removed. Go itself already flattens typedefs, but this
-// handles it in the unlikely event that we ever fix that.
+// handles it in the unlikely event that we ever fix that. It also resolves
+// generic type parameters and instantiated named types to their structural
+// form, via structuralType.
 //
 // Examples:
 // * Trivial:
@@ -69,6 +174,10 @@ func isNilableType(t *types.Type) bool {
 //   - given `type X *int; *X`, returns `**int`
 //   - given `type X []int; X`, returns `[]int`
 //   - given `type X []int; *X`, returns `*[]int`
+//
+// * Generics:
+//   - given `type List[T any] []T; List[Pod]`, returns `[]Pod`
+//   - given a type parameter `T` with structural type `int`, returns `int`
 func nativeType(t *types.Type) *types.Type {
 	ptrs := 0
 	for {
@@ -77,6 +186,8 @@ func nativeType(t *types.Type) *types.Type {
 		} else if t.Kind == types.Pointer {
 			ptrs++
 			t = t.Elem
+		} else if t.Kind == types.TypeParam || (len(t.TypeArgs) > 0 && t.Underlying != nil) {
+			t = structuralType(t)
 		} else {
 			break
 		}
@@ -88,10 +199,21 @@ func nativeType(t *types.Type) *types.Type {
 }
 
 // nonPointer returns the value-type of a possibly pointer type. If type is not
-// a pointer, it returns the input type.
+// a pointer, it returns the input type. A type parameter whose structural
+// type is a pointer is treated as a pointer.
 func nonPointer(t *types.Type) *types.Type {
-	for t.Kind == types.Pointer {
-		t = t.Elem
+	for {
+		if t.Kind == types.Pointer {
+			t = t.Elem
+			continue
+		}
+		if t.Kind == types.TypeParam {
+			if s := structuralType(t); s.Kind == types.Pointer {
+				t = s.Elem
+				continue
+			}
+		}
+		break
 	}
 	return t
 }
@@ -0,0 +1,284 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/v2/types"
+)
+
+func newBuiltin(name string) *types.Type {
+	return &types.Type{Name: types.Name{Name: name}, Kind: types.Builtin}
+}
+
+func newStruct(name string, members ...types.Member) *types.Type {
+	return &types.Type{Name: types.Name{Name: name}, Kind: types.Struct, Members: members}
+}
+
+func jsonField(name, jsonName string, t *types.Type) types.Member {
+	return types.Member{Name: name, Tags: `json:"` + jsonName + `"`, Type: t}
+}
+
+func inlineField(name string, t *types.Type) types.Member {
+	return types.Member{Name: name, Tags: `json:",inline"`, Type: t}
+}
+
+func embeddedField(t *types.Type) types.Member {
+	return types.Member{Name: t.Name.Name, Embedded: true, Type: t}
+}
+
+func TestGetMemberByJSON(t *testing.T) {
+	base := newStruct("Base", jsonField("ID", "id", newBuiltin("string")))
+	core := newStruct("ConditionsCore", jsonField("Ready", "ready", newBuiltin("bool")))
+
+	inlineParent := newStruct("Status", inlineField("Core", core), jsonField("Message", "message", newBuiltin("string")))
+	embedParent := newStruct("Child", embeddedField(base), jsonField("Name", "name", newBuiltin("string")))
+
+	cases := []struct {
+		name     string
+		in       *types.Type
+		json     string
+		want     *types.Member
+		wantHops []*types.Member
+	}{
+		{"top-level field", inlineParent, "message", &inlineParent.Members[1], nil},
+		{"promoted via inline", inlineParent, "ready", &core.Members[0], []*types.Member{&inlineParent.Members[0]}},
+		{"promoted via anonymous embed", embedParent, "id", &base.Members[0], []*types.Member{&embedParent.Members[0]}},
+		{"direct field alongside embed", embedParent, "name", &embedParent.Members[1], nil},
+		{"missing field", inlineParent, "nonexistent", nil, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gotHops := getMemberByJSON(tc.in, tc.json)
+			if got != tc.want {
+				t.Errorf("getMemberByJSON(%s, %q) member = %v, want %v", tc.in.Name.Name, tc.json, got, tc.want)
+			}
+			if len(gotHops) != len(tc.wantHops) {
+				t.Fatalf("getMemberByJSON(%s, %q) hops = %v, want %v", tc.in.Name.Name, tc.json, gotHops, tc.wantHops)
+			}
+			for i := range tc.wantHops {
+				if gotHops[i] != tc.wantHops[i] {
+					t.Errorf("getMemberByJSON(%s, %q) hops[%d] = %v, want %v", tc.in.Name.Name, tc.json, i, gotHops[i], tc.wantHops[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetMemberByJSONPath(t *testing.T) {
+	metadata := newStruct("ObjectMeta", jsonField("Name", "name", newBuiltin("string")))
+	template := newStruct("Template", jsonField("Metadata", "metadata", metadata))
+	spec := newStruct("Spec", jsonField("Template", "template", template))
+	root := newStruct("Root", jsonField("Spec", "spec", spec))
+
+	rootWithPointerSpec := newStruct("RootPtr", jsonField("Spec", "spec", types.PointerTo(spec)))
+
+	core := newStruct("ConditionsCore", jsonField("Ready", "ready", newBuiltin("bool")))
+	status := newStruct("Status", inlineField("Core", core))
+	rootWithInline := newStruct("RootInline", jsonField("Status", "status", status))
+
+	corePtr := newStruct("ConditionsCorePtr", jsonField("Ready", "ready", newBuiltin("bool")))
+	statusWithPointerInline := newStruct("StatusPtr", inlineField("Core", types.PointerTo(corePtr)))
+	rootWithPointerInline := newStruct("RootPointerInline", jsonField("Status", "status", statusWithPointerInline))
+
+	t.Run("nested struct path", func(t *testing.T) {
+		gotMember, gotParents, err := getMemberByJSONPath(root, "spec.template.metadata.name")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotMember != &metadata.Members[0] {
+			t.Errorf("terminal member = %v, want %v", gotMember, &metadata.Members[0])
+		}
+		wantParents := []*types.Member{&root.Members[0], &spec.Members[0], &template.Members[0]}
+		if len(gotParents) != len(wantParents) {
+			t.Fatalf("parents = %v, want %v", gotParents, wantParents)
+		}
+		for i := range wantParents {
+			if gotParents[i] != wantParents[i] {
+				t.Errorf("parents[%d] = %v, want %v", i, gotParents[i], wantParents[i])
+			}
+		}
+	})
+
+	t.Run("pointer hop recorded in parents", func(t *testing.T) {
+		_, gotParents, err := getMemberByJSONPath(rootWithPointerSpec, "spec.template.metadata.name")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(gotParents) == 0 {
+			t.Fatalf("expected at least one parent, got none")
+		}
+		specMember := gotParents[0]
+		if !isNilableType(specMember.Type) {
+			t.Errorf("parents[0] (%s) should be a pointer hop requiring a nil guard", specMember.Name)
+		}
+	})
+
+	t.Run("traverses inline fields transparently", func(t *testing.T) {
+		gotMember, gotParents, err := getMemberByJSONPath(rootWithInline, "status.ready")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotMember != &core.Members[0] {
+			t.Errorf("terminal member = %v, want %v", gotMember, &core.Members[0])
+		}
+		wantParents := []*types.Member{&rootWithInline.Members[0], &status.Members[0]}
+		if len(gotParents) != len(wantParents) {
+			t.Fatalf("parents = %v, want %v", gotParents, wantParents)
+		}
+		for i := range wantParents {
+			if gotParents[i] != wantParents[i] {
+				t.Errorf("parents[%d] = %v, want %v", i, gotParents[i], wantParents[i])
+			}
+		}
+	})
+
+	t.Run("pointer inline hop is recorded in parents", func(t *testing.T) {
+		// Core is `json:",inline"` AND a pointer: the pointer hop lives
+		// entirely inside getMemberByJSON's own embedded/inline descent,
+		// not at a top-level path segment, so it must still surface in
+		// the returned parents chain for nil-guard purposes.
+		gotMember, gotParents, err := getMemberByJSONPath(rootWithPointerInline, "status.ready")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotMember != &corePtr.Members[0] {
+			t.Errorf("terminal member = %v, want %v", gotMember, &corePtr.Members[0])
+		}
+		wantParents := []*types.Member{&rootWithPointerInline.Members[0], &statusWithPointerInline.Members[0]}
+		if len(gotParents) != len(wantParents) {
+			t.Fatalf("parents = %v, want %v", gotParents, wantParents)
+		}
+		for i := range wantParents {
+			if gotParents[i] != wantParents[i] {
+				t.Errorf("parents[%d] = %v, want %v", i, gotParents[i], wantParents[i])
+			}
+		}
+		corePtrMember := gotParents[len(gotParents)-1]
+		if !isNilableType(corePtrMember.Type) {
+			t.Errorf("parents[%d] (%s) should be the pointer inline hop requiring a nil guard", len(gotParents)-1, corePtrMember.Name)
+		}
+	})
+
+	t.Run("empty path", func(t *testing.T) {
+		if _, _, err := getMemberByJSONPath(root, ""); err == nil {
+			t.Fatal("expected an error for an empty path, got nil")
+		}
+	})
+
+	t.Run("missing segment", func(t *testing.T) {
+		_, _, err := getMemberByJSONPath(root, "spec.nonexistent")
+		if err == nil {
+			t.Fatal("expected an error for a missing segment, got nil")
+		}
+		if !strings.Contains(err.Error(), "nonexistent") {
+			t.Errorf("error %q should mention the missing segment", err.Error())
+		}
+	})
+
+	t.Run("non-struct descent", func(t *testing.T) {
+		_, _, err := getMemberByJSONPath(root, "spec.template.metadata.name.extra")
+		if err == nil {
+			t.Fatal("expected an error descending into a non-struct field, got nil")
+		}
+		if !strings.Contains(err.Error(), "not a struct") {
+			t.Errorf("error %q should explain the field is not a struct", err.Error())
+		}
+	})
+}
+
+// newTypeParam builds a synthetic type parameter whose constraint's
+// structural type is underlying (e.g. `any`'s structural type is an empty
+// interface; `int`'s structural type is itself).
+func newTypeParam(name string, underlying *types.Type) *types.Type {
+	return &types.Type{Name: types.Name{Name: name}, Kind: types.TypeParam, Underlying: underlying}
+}
+
+// newInstantiation builds a synthetic instantiated named type (e.g.
+// `List[Pod]`), with its post-substitution body recorded on Underlying, the
+// way `type List[T any] []T` instantiated with Pod would be represented.
+func newInstantiation(name string, typeArgs []*types.Type, underlying *types.Type) *types.Type {
+	return &types.Type{Name: types.Name{Name: name}, Kind: types.Struct, TypeArgs: typeArgs, Underlying: underlying}
+}
+
+func TestStructuralTypeAndFriendsWithGenerics(t *testing.T) {
+	pod := newStruct("Pod", jsonField("Name", "name", newBuiltin("string")))
+	podList := &types.Type{Kind: types.Slice, Elem: pod} // the substituted []Pod body
+
+	listPod := newInstantiation("List", []*types.Type{pod}, podList)
+
+	anyIface := &types.Type{Kind: types.Interface}
+	tAny := newTypeParam("T", anyIface)
+	tInt := newTypeParam("U", newBuiltin("int"))
+	tPtr := newTypeParam("P", types.PointerTo(newBuiltin("int")))
+
+	t.Run("nativeType collapses an instantiated named type", func(t *testing.T) {
+		got := nativeType(listPod)
+		if got.Kind != types.Slice || got.Elem != pod {
+			t.Errorf("nativeType(List[Pod]) = %s, want []Pod", got)
+		}
+	})
+
+	t.Run("nativeType preserves pointer count over an instantiated named type", func(t *testing.T) {
+		got := nativeType(types.PointerTo(listPod))
+		if got.Kind != types.Pointer || got.Elem.Kind != types.Slice || got.Elem.Elem != pod {
+			t.Errorf("nativeType(*List[Pod]) = %s, want *[]Pod", got)
+		}
+	})
+
+	t.Run("nativeType resolves a type parameter to its structural type", func(t *testing.T) {
+		got := nativeType(tInt)
+		if got.Kind != types.Builtin || got.Name.Name != "int" {
+			t.Errorf("nativeType(U int) = %s, want int", got)
+		}
+	})
+
+	t.Run("nativeType preserves pointer count over a type parameter", func(t *testing.T) {
+		got := nativeType(types.PointerTo(tAny))
+		if got.Kind != types.Pointer || got.Elem.Kind != types.Interface {
+			t.Errorf("nativeType(*T any) = %s, want *interface{}", got)
+		}
+	})
+
+	t.Run("nonPointer looks through a type parameter whose constraint is a pointer", func(t *testing.T) {
+		got := nonPointer(tPtr)
+		if got.Kind != types.Builtin || got.Name.Name != "int" {
+			t.Errorf("nonPointer(P *int) = %s, want int", got)
+		}
+	})
+
+	t.Run("nonPointer is a no-op for a non-pointer type parameter", func(t *testing.T) {
+		got := nonPointer(tInt)
+		if got != tInt {
+			t.Errorf("nonPointer(U int) = %s, want U unchanged", got)
+		}
+	})
+
+	t.Run("isNilableType is true for a type parameter constrained to any", func(t *testing.T) {
+		if !isNilableType(tAny) {
+			t.Errorf("isNilableType(T any) = false, want true")
+		}
+	})
+
+	t.Run("isNilableType is false for a type parameter constrained to int", func(t *testing.T) {
+		if isNilableType(tInt) {
+			t.Errorf("isNilableType(U int) = true, want false")
+		}
+	})
+}